@@ -2,10 +2,17 @@ package config
 
 import (
 	"bufio"
+	"context"
 	"errors"
+	"fmt"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 type ConfigInterface interface {
@@ -16,58 +23,294 @@ type ConfigInterface interface {
 }
 
 type config struct {
+	mu     sync.RWMutex
 	values map[string]string
+	files  []string
 }
 
+var (
+	sectionLine      = regexp.MustCompile(`^\[([A-Za-z0-9_.-]+)\]$`)
+	keyValueLine     = regexp.MustCompile(`^[A-Za-z0-9_-]+\s*=`)
+	trailingComment  = regexp.MustCompile(`\s+#.*$`)
+	interpolationRef = regexp.MustCompile(`\$\{([A-Za-z0-9_.-]+)(:-([^}]*))?\}`)
+)
+
+// Load parses a single `.env`-style file. It's a shim over LoadMulti for
+// the common single-file case.
 func Load(filename string) (*config, error) {
+	return LoadMulti(filename)
+}
 
+// LoadMulti parses one or more `.env`-style files in order, with keys in
+// later files overriding the same key from earlier files. Supported
+// syntax: `#` line and trailing comments, blank lines, single- and
+// double-quoted values (double-quoted values support `\n`/`\t` escapes
+// and both kinds may span multiple physical lines), `${VAR}` /
+// `${VAR:-default}` interpolation resolved against keys loaded so far
+// and then os.Environ(), and `[section]` headers that prefix subsequent
+// keys as `section.key`.
+func LoadMulti(files ...string) (*config, error) {
+	values := make(map[string]string)
+	for _, filename := range files {
+		if err := parseFile(filename, values); err != nil {
+			return nil, err
+		}
+	}
+	return &config{values: values, files: append([]string{}, files...)}, nil
+}
+
+func parseFile(filename string, values map[string]string) error {
 	f, err := os.Open(filename)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer f.Close()
 
-	r := bufio.NewReader(f)
-	line, isPrefix, err := r.ReadLine()
-	keyValue := regexp.MustCompile(`^[A-Za-z0-9_-]+\s*=.*$`)
-	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 
-	for err == nil && !isPrefix {
+	section := ""
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
 
-		s := string(line)
+		if m := sectionLine.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			continue
+		}
+
+		if !keyValueLine.MatchString(line) {
+			continue
+		}
 
-		if keyValue.MatchString(s) {
-			delimIndex := strings.Index(s, "=")
-			key := strings.Trim(s[:delimIndex], " ")
-			value := strings.Trim(s[delimIndex+1:], " ")
+		delimIndex := strings.Index(line, "=")
+		key := strings.TrimSpace(line[:delimIndex])
+		rawValue := strings.TrimSpace(line[delimIndex+1:])
+
+		value, literal, err := readValue(rawValue, scanner)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", filename, err)
+		}
+
+		if section != "" {
+			key = section + "." + key
+		}
+		if literal {
+			// single-quoted: conventionally the escape hatch out of
+			// interpolation, so a value can contain a literal "${...}"
 			values[key] = value
+		} else {
+			values[key] = interpolate(value, values)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readValue extracts a single value starting at rawValue, pulling in
+// further lines from scanner when a quoted value isn't closed on its
+// opening line. literal reports whether the value was single-quoted,
+// which - as in dotenv, python-dotenv, and docker-compose - takes the
+// value completely literally and opts it out of "${VAR}" interpolation.
+func readValue(rawValue string, scanner *bufio.Scanner) (value string, literal bool, err error) {
+	switch {
+	case strings.HasPrefix(rawValue, `"`):
+		body, closed := rawValue[1:], false
+		for {
+			if idx := unescapedQuoteIndex(body, '"'); idx >= 0 {
+				body, closed = body[:idx], true
+				break
+			}
+			if !scanner.Scan() {
+				break
+			}
+			body += "\n" + scanner.Text()
+		}
+		if !closed {
+			return "", false, errors.New("unterminated double-quoted value")
+		}
+		return unescapeDouble(body), false, nil
+
+	case strings.HasPrefix(rawValue, `'`):
+		body, closed := rawValue[1:], false
+		for {
+			if idx := strings.IndexByte(body, '\''); idx >= 0 {
+				body, closed = body[:idx], true
+				break
+			}
+			if !scanner.Scan() {
+				break
+			}
+			body += "\n" + scanner.Text()
 		}
+		if !closed {
+			return "", true, errors.New("unterminated single-quoted value")
+		}
+		return body, true, nil
 
-		line, isPrefix, err = r.ReadLine()
+	default:
+		return strings.TrimSpace(trailingComment.ReplaceAllString(rawValue, "")), false, nil
 	}
+}
 
-	if isPrefix {
-		return nil, errors.New("config file line exceeded read buffer size")
+func unescapedQuoteIndex(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == quote {
+			return i
+		}
 	}
+	return -1
+}
 
-	return &config{values}, nil
+func unescapeDouble(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case 't':
+				b.WriteByte('\t')
+				i++
+				continue
+			case '"', '\\':
+				b.WriteByte(s[i+1])
+				i++
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
 }
 
-func (c config) Get(key string) string {
+// interpolate resolves ${KEY} / ${KEY:-default} references against keys
+// already loaded (including earlier files in a LoadMulti call), then
+// os.Environ(), then the inline default, falling back to an empty
+// string.
+func interpolate(value string, values map[string]string) string {
+	return interpolationRef.ReplaceAllStringFunc(value, func(ref string) string {
+		m := interpolationRef.FindStringSubmatch(ref)
+		name, hasDefault, def := m[1], m[2] != "", m[3]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		if hasDefault {
+			return def
+		}
+		return ""
+	})
+}
+
+func (c *config) Get(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.values[key]
 }
 
-func (c config) Has(key string) bool {
+func (c *config) Has(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.values[key] != ""
 }
 
-func (c config) GetWithFallback(key string, fallback string) string {
+func (c *config) GetWithFallback(key string, fallback string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if c.values[key] != "" {
 		return c.values[key]
 	}
 	return fallback
 }
 
-func (c config) All() map[string]string {
-	return c.values
+func (c *config) All() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	all := make(map[string]string, len(c.values))
+	for k, v := range c.values {
+		all[k] = v
+	}
+	return all
+}
+
+func (c *config) GetInt(key string) (int, error) {
+	return strconv.Atoi(c.Get(key))
+}
+
+func (c *config) GetBool(key string) (bool, error) {
+	return strconv.ParseBool(c.Get(key))
+}
+
+func (c *config) GetDuration(key string) (time.Duration, error) {
+	return time.ParseDuration(c.Get(key))
+}
+
+// Watch reloads the config's files atomically whenever any of them
+// change on disk, using fsnotify, until ctx is canceled. A reload that
+// fails to parse is logged to nothing and simply skipped, leaving the
+// previous values in place.
+func (c *config) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	for _, filename := range c.files {
+		if err := watcher.Add(filename); err != nil {
+			watcher.Close()
+			return err
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				reloaded := make(map[string]string)
+				reloadOK := true
+				for _, filename := range c.files {
+					if err := parseFile(filename, reloaded); err != nil {
+						reloadOK = false
+						break
+					}
+				}
+				if reloadOK {
+					c.mu.Lock()
+					c.values = reloaded
+					c.mu.Unlock()
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
 }
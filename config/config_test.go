@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadSingleQuotedValueIsNotInterpolated(t *testing.T) {
+	t.Setenv("SECRET_ENV", "leaked-from-environment")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.env")
+	contents := "LITERAL='${SECRET_ENV}'\nEXPANDED=\"${SECRET_ENV}\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := cfg.Get("LITERAL"); got != "${SECRET_ENV}" {
+		t.Errorf(`Get("LITERAL") = %q, want literal "${SECRET_ENV}"`, got)
+	}
+	if got := cfg.Get("EXPANDED"); got != "leaked-from-environment" {
+		t.Errorf(`Get("EXPANDED") = %q, want interpolated "leaked-from-environment"`, got)
+	}
+}
+
+func TestLoadSectionHeadersPrefixKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.env")
+	contents := "NAME=top-level\n\n[database]\nHOST=localhost\nPORT=5432\n\n[cache]\nHOST=redis\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := cfg.Get("NAME"); got != "top-level" {
+		t.Errorf(`Get("NAME") = %q, want "top-level"`, got)
+	}
+	if got := cfg.Get("database.HOST"); got != "localhost" {
+		t.Errorf(`Get("database.HOST") = %q, want "localhost"`, got)
+	}
+	if got := cfg.Get("database.PORT"); got != "5432" {
+		t.Errorf(`Get("database.PORT") = %q, want "5432"`, got)
+	}
+	if got := cfg.Get("cache.HOST"); got != "redis" {
+		t.Errorf(`Get("cache.HOST") = %q, want "redis"`, got)
+	}
+}
+
+func TestLoadMultiLaterFilesOverrideEarlierOnes(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.env")
+	overridePath := filepath.Join(dir, "override.env")
+	if err := os.WriteFile(basePath, []byte("NAME=base\nSHARED=base-value\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(overridePath, []byte("SHARED=override-value\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := LoadMulti(basePath, overridePath)
+	if err != nil {
+		t.Fatalf("LoadMulti: %v", err)
+	}
+
+	if got := cfg.Get("NAME"); got != "base" {
+		t.Errorf(`Get("NAME") = %q, want "base"`, got)
+	}
+	if got := cfg.Get("SHARED"); got != "override-value" {
+		t.Errorf(`Get("SHARED") = %q, want "override-value"`, got)
+	}
+}
+
+func TestTypedAccessors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.env")
+	contents := "COUNT=42\nENABLED=true\nTIMEOUT=1500ms\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	count, err := cfg.GetInt("COUNT")
+	if err != nil || count != 42 {
+		t.Errorf("GetInt(COUNT) = %d, %v, want 42, nil", count, err)
+	}
+
+	enabled, err := cfg.GetBool("ENABLED")
+	if err != nil || !enabled {
+		t.Errorf("GetBool(ENABLED) = %v, %v, want true, nil", enabled, err)
+	}
+
+	timeout, err := cfg.GetDuration("TIMEOUT")
+	if err != nil || timeout != 1500*time.Millisecond {
+		t.Errorf("GetDuration(TIMEOUT) = %v, %v, want 1500ms, nil", timeout, err)
+	}
+
+	if _, err := cfg.GetInt("ENABLED"); err == nil {
+		t.Errorf("GetInt(ENABLED): expected an error for a non-numeric value")
+	}
+}
+
+func TestWatchReloadsOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.env")
+	if err := os.WriteFile(path, []byte("NAME=original\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := cfg.Watch(ctx); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("NAME=updated\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile (update): %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cfg.Get("NAME") == "updated" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Get(NAME) = %q after update, want %q", cfg.Get("NAME"), "updated")
+}
@@ -5,8 +5,20 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
+)
+
+const (
+	version = "v1"
+
+	// streamChunkSize is the amount of plaintext sealed per GCM chunk in
+	// EncryptStream/DecryptStream, keeping memory use flat regardless of
+	// how large the underlying stream is.
+	streamChunkSize = 64 * 1024
 )
 
 type CryptoInterface interface {
@@ -14,12 +26,31 @@ type CryptoInterface interface {
 	Decrypt(data string) (string, error)
 }
 
+// crypto holds a registry of keys identified by a short key ID (kid),
+// with one of them marked active for new encryptions. Decrypt resolves
+// the kid embedded in the ciphertext, so old ciphertexts keep decrypting
+// across a key rotation as long as their kid's key is still present.
 type crypto struct {
-	key string
+	keys      map[string]string // kid -> base64-encoded key
+	activeKID string
 }
 
+// defaultKID is the kid used by New's single-key shim.
+const defaultKID = "default"
+
+// New is a shim over NewWithKeys for the common single-key case.
 func New(key string) crypto {
-	return crypto{key}
+	return NewWithKeys(map[string]string{defaultKID: key}, defaultKID)
+}
+
+// NewWithKeys builds a crypto value backed by multiple keys, e.g.
+// NewWithKeys(map[string]string{"2024": oldKey, "2025": newKey}, "2025").
+// Encrypt always uses the activeKID; Decrypt picks whichever key the
+// ciphertext's embedded kid names, which is what makes key rotation
+// seamless: mint new ciphertext with the new kid while old ciphertext
+// tagged with the retired kid still decrypts.
+func NewWithKeys(keys map[string]string, activeKID string) crypto {
+	return crypto{keys: keys, activeKID: activeKID}
 }
 
 func GenerateKey() string {
@@ -29,18 +60,48 @@ func GenerateKey() string {
 }
 
 func (c crypto) Encrypt(data string) (string, error) {
+	return c.EncryptWithAAD(data, nil)
+}
 
-	key, err := base64.StdEncoding.DecodeString(c.key)
-	if err != nil {
-		return "", errors.New("crypto key could not be decoded")
-	}
+func (c crypto) Decrypt(data string) (string, error) {
+	return c.DecryptWithAAD(data, nil)
+}
 
-	cb, err := aes.NewCipher(key)
+// EncryptBytes is Encrypt for binary payloads, so callers don't have to
+// force a lossy string round-trip on arbitrary bytes.
+func (c crypto) EncryptBytes(data []byte) (string, error) {
+	return c.encrypt(data, nil)
+}
+
+// DecryptBytes is Decrypt for binary payloads, returning the decrypted
+// bytes directly instead of forcing them through a string.
+func (c crypto) DecryptBytes(data string) ([]byte, error) {
+	return c.decrypt(data, nil)
+}
+
+// EncryptWithAAD is Encrypt with additional authenticated data: aad is
+// not encrypted but must match on Decrypt/DecryptWithAAD or the seal
+// will fail to open, letting callers bind a ciphertext to a context
+// (e.g. a record ID) without including it in the plaintext.
+func (c crypto) EncryptWithAAD(data string, aad []byte) (string, error) {
+	return c.encrypt([]byte(data), aad)
+}
+
+func (c crypto) DecryptWithAAD(data string, aad []byte) (string, error) {
+	plaintext, err := c.decrypt(data, aad)
 	if err != nil {
 		return "", err
 	}
+	return string(plaintext), nil
+}
+
+func (c crypto) encrypt(data []byte, aad []byte) (string, error) {
+	key, ok := c.keys[c.activeKID]
+	if !ok {
+		return "", fmt.Errorf("crypto: no key registered for active kid %q", c.activeKID)
+	}
 
-	gcm, err := cipher.NewGCM(cb)
+	gcm, err := c.gcmForKey(key)
 	if err != nil {
 		return "", err
 	}
@@ -50,43 +111,166 @@ func (c crypto) Encrypt(data string) (string, error) {
 		return "", err
 	}
 
-	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(data), nil)), nil
+	sealed := gcm.Seal(nonce, nonce, data, aad)
+	payload := base64.StdEncoding.EncodeToString(sealed)
+	return fmt.Sprintf("%s.%s.%s", version, c.activeKID, payload), nil
 }
 
-func (c crypto) Decrypt(data string) (string, error) {
-
-	key, err := base64.StdEncoding.DecodeString(c.key)
-	if err != nil {
-		return "", errors.New("crypto key could not be decoded")
+func (c crypto) decrypt(data string, aad []byte) ([]byte, error) {
+	parts := strings.SplitN(data, ".", 3)
+	if len(parts) != 3 || parts[0] != version {
+		return nil, errors.New("crypto: ciphertext is not a recognized v1.<kid>.<payload> envelope")
 	}
+	kid, payload := parts[1], parts[2]
 
-	cb, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
+	key, ok := c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("crypto: no key registered for kid %q", kid)
 	}
 
-	gcm, err := cipher.NewGCM(cb)
+	gcm, err := c.gcmForKey(key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	dataBytes, err := base64.StdEncoding.DecodeString(data)
+	dataBytes, err := base64.StdEncoding.DecodeString(payload)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	nonceSize := gcm.NonceSize()
 	if len(dataBytes) < nonceSize {
-		return "", errors.New("data is too short")
+		return nil, errors.New("data is too short")
 	}
 
 	nonce := dataBytes[:nonceSize]
 	dataBytes = dataBytes[nonceSize:]
 
-	decrypted, err := gcm.Open(nil, []byte(nonce), dataBytes, nil)
+	return gcm.Open(nil, nonce, dataBytes, aad)
+}
+
+func (c crypto) gcmForKey(base64Key string) (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(base64Key)
 	if err != nil {
-		return "", err
+		return nil, errors.New("crypto key could not be decoded")
 	}
 
-	return string(decrypted), nil
+	cb, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(cb)
+}
+
+// EncryptStream seals r in fixed-size chunks as it's read and writes
+// them to w, so encrypting a file larger than memory never requires
+// holding the whole plaintext (or ciphertext) at once. The stream starts
+// with a "v1.<kid>\n" header identifying the active key, followed by a
+// sequence of 4-byte big-endian length-prefixed sealed chunks.
+func (c crypto) EncryptStream(r io.Reader, w io.Writer) error {
+	key, ok := c.keys[c.activeKID]
+	if !ok {
+		return fmt.Errorf("crypto: no key registered for active kid %q", c.activeKID)
+	}
+
+	gcm, err := c.gcmForKey(key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "%s.%s\n", version, c.activeKID); err != nil {
+		return err
+	}
+
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			nonce := make([]byte, gcm.NonceSize())
+			if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+				return err
+			}
+			sealed := gcm.Seal(nonce, nonce, buf[:n], nil)
+			if err := binary.Write(w, binary.BigEndian, uint32(len(sealed))); err != nil {
+				return err
+			}
+			if _, err := w.Write(sealed); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// DecryptStream reverses EncryptStream, reading the kid header from r
+// and writing the decrypted plaintext chunks to w as it goes.
+func (c crypto) DecryptStream(r io.Reader, w io.Writer) error {
+	header, err := readStreamHeader(r)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(header, ".", 2)
+	if len(parts) != 2 || parts[0] != version {
+		return errors.New("crypto: stream is not a recognized v1.<kid> envelope")
+	}
+	kid := parts[1]
+
+	key, ok := c.keys[kid]
+	if !ok {
+		return fmt.Errorf("crypto: no key registered for kid %q", kid)
+	}
+
+	gcm, err := c.gcmForKey(key)
+	if err != nil {
+		return err
+	}
+
+	nonceSize := gcm.NonceSize()
+	for {
+		var chunkLen uint32
+		if err := binary.Read(r, binary.BigEndian, &chunkLen); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		chunk := make([]byte, chunkLen)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return err
+		}
+		if len(chunk) < nonceSize {
+			return errors.New("crypto: stream chunk is too short")
+		}
+
+		nonce, ciphertext := chunk[:nonceSize], chunk[nonceSize:]
+		plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return err
+		}
+	}
+}
+
+func readStreamHeader(r io.Reader) (string, error) {
+	var b strings.Builder
+	buf := make([]byte, 1)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", err
+		}
+		if buf[0] == '\n' {
+			return b.String(), nil
+		}
+		b.WriteByte(buf[0])
+	}
 }
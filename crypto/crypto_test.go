@@ -0,0 +1,129 @@
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	c := New(GenerateKey())
+
+	encrypted, err := c.Encrypt("hello world")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, "v1.default.") {
+		t.Errorf("Encrypt() = %q, want a v1.default.<payload> envelope", encrypted)
+	}
+
+	decrypted, err := c.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if decrypted != "hello world" {
+		t.Errorf("Decrypt() = %q, want %q", decrypted, "hello world")
+	}
+}
+
+func TestEncryptBytesDecryptBytesRoundTrip(t *testing.T) {
+	c := New(GenerateKey())
+	data := []byte{0x00, 0x01, 0xff, 0xfe}
+
+	encrypted, err := c.EncryptBytes(data)
+	if err != nil {
+		t.Fatalf("EncryptBytes: %v", err)
+	}
+	decrypted, err := c.DecryptBytes(encrypted)
+	if err != nil {
+		t.Fatalf("DecryptBytes: %v", err)
+	}
+	if !bytes.Equal(decrypted, data) {
+		t.Errorf("DecryptBytes() = %v, want %v", decrypted, data)
+	}
+}
+
+func TestEncryptWithAADRequiresMatchingAAD(t *testing.T) {
+	c := New(GenerateKey())
+
+	encrypted, err := c.EncryptWithAAD("hello", []byte("record-1"))
+	if err != nil {
+		t.Fatalf("EncryptWithAAD: %v", err)
+	}
+
+	if _, err := c.DecryptWithAAD(encrypted, []byte("record-2")); err == nil {
+		t.Errorf("DecryptWithAAD: expected an error for mismatched AAD")
+	}
+
+	decrypted, err := c.DecryptWithAAD(encrypted, []byte("record-1"))
+	if err != nil {
+		t.Fatalf("DecryptWithAAD with matching AAD: %v", err)
+	}
+	if decrypted != "hello" {
+		t.Errorf("DecryptWithAAD() = %q, want %q", decrypted, "hello")
+	}
+}
+
+func TestKeyRotationDecryptsOldAndNewCiphertext(t *testing.T) {
+	oldKey, newKey := GenerateKey(), GenerateKey()
+
+	before := NewWithKeys(map[string]string{"2024": oldKey}, "2024")
+	oldEncrypted, err := before.Encrypt("secret from 2024")
+	if err != nil {
+		t.Fatalf("Encrypt (old): %v", err)
+	}
+
+	after := NewWithKeys(map[string]string{"2024": oldKey, "2025": newKey}, "2025")
+
+	decrypted, err := after.Decrypt(oldEncrypted)
+	if err != nil {
+		t.Fatalf("Decrypt old ciphertext after rotation: %v", err)
+	}
+	if decrypted != "secret from 2024" {
+		t.Errorf("Decrypt() = %q, want %q", decrypted, "secret from 2024")
+	}
+
+	newEncrypted, err := after.Encrypt("secret from 2025")
+	if err != nil {
+		t.Fatalf("Encrypt (new): %v", err)
+	}
+	if !strings.HasPrefix(newEncrypted, "v1.2025.") {
+		t.Errorf("Encrypt() = %q, want a v1.2025.<payload> envelope", newEncrypted)
+	}
+	if decrypted, err := after.Decrypt(newEncrypted); err != nil || decrypted != "secret from 2025" {
+		t.Errorf("Decrypt(new) = %q, %v, want %q, nil", decrypted, err, "secret from 2025")
+	}
+}
+
+func TestDecryptUnknownKidFails(t *testing.T) {
+	c := NewWithKeys(map[string]string{"2025": GenerateKey()}, "2025")
+	if _, err := c.Decrypt("v1.1999.abc123"); err == nil {
+		t.Errorf("Decrypt: expected an error for an unregistered kid")
+	}
+}
+
+func TestEncryptStreamDecryptStreamRoundTrip(t *testing.T) {
+	c := New(GenerateKey())
+	plaintext := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog. "), 10000)
+
+	var ciphertext bytes.Buffer
+	if err := c.EncryptStream(bytes.NewReader(plaintext), &ciphertext); err != nil {
+		t.Fatalf("EncryptStream: %v", err)
+	}
+
+	var decrypted bytes.Buffer
+	if err := c.DecryptStream(&ciphertext, &decrypted); err != nil {
+		t.Fatalf("DecryptStream: %v", err)
+	}
+
+	if !bytes.Equal(decrypted.Bytes(), plaintext) {
+		t.Errorf("DecryptStream round trip mismatch: got %d bytes, want %d bytes", decrypted.Len(), len(plaintext))
+	}
+}
+
+func TestDecryptStreamRejectsUnrecognizedHeader(t *testing.T) {
+	c := New(GenerateKey())
+	if err := c.DecryptStream(strings.NewReader("v2.default\n"), &bytes.Buffer{}); err == nil {
+		t.Errorf("DecryptStream: expected an error for an unrecognized envelope version")
+	}
+}
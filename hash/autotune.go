@@ -0,0 +1,26 @@
+package hash
+
+import "time"
+
+// AutoTuneArgon2id doubles start's Iterations until a single hash takes
+// at least target or Iterations reaches 64 or more, so a service can pick Argon2id cost parameters
+// suited to the hardware it's actually running on the first time it
+// starts, instead of shipping one hardcoded guess for every deployment.
+func AutoTuneArgon2id(target time.Duration, start Argon2idParams) Argon2idParams {
+	params := start
+	if params.Iterations == 0 {
+		params.Iterations = 1
+	}
+	for params.Iterations < 64 {
+		hasher := &argon2idHasher{params: params}
+		began := time.Now()
+		if _, err := hasher.Hash("autotune-benchmark"); err != nil {
+			break
+		}
+		if time.Since(began) >= target {
+			break
+		}
+		params.Iterations *= 2
+	}
+	return params
+}
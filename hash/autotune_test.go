@@ -0,0 +1,28 @@
+package hash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoTuneArgon2idRaisesIterationsTowardTarget(t *testing.T) {
+	start := Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	target := 200 * time.Millisecond
+
+	params := AutoTuneArgon2id(target, start)
+
+	if params.Iterations <= start.Iterations {
+		t.Fatalf("Iterations = %d, want doubled past start's %d to approach a %v target", params.Iterations, start.Iterations, target)
+	}
+}
+
+func TestAutoTuneArgon2idStopsAtIterationsCap(t *testing.T) {
+	// A target no amount of doubling will reach forces the cap to kick in
+	// rather than looping forever.
+	start := Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	params := AutoTuneArgon2id(time.Hour, start)
+
+	if params.Iterations != 64 {
+		t.Errorf("Iterations = %d, want 64 (the loop's cap)", params.Iterations)
+	}
+}
@@ -0,0 +1,47 @@
+package hash
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher builds a Hasher backed by bcrypt at the given cost.
+func NewBcryptHasher(cost int) Hasher {
+	return &bcryptHasher{cost: cost}
+}
+
+func (b *bcryptHasher) Hash(password string) (string, error) {
+	encoded, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func (b *bcryptHasher) Verify(password string, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == nil {
+		return true, nil
+	}
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, nil
+	}
+	return false, err
+}
+
+func (b *bcryptHasher) Matches(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func (b *bcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return true
+	}
+	return cost != b.cost
+}
@@ -2,15 +2,75 @@ package hash
 
 import "golang.org/x/crypto/bcrypt"
 
-func Make(data string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(data), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
+// Hasher hashes and verifies passwords using a specific algorithm. Each
+// implementation encodes its own PHC-style string (e.g.
+// "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>"), so Check can
+// dispatch to the right Hasher purely from an encoded hash's prefix via
+// Matches.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(password string, encoded string) (bool, error)
+	Matches(encoded string) bool
+	// NeedsRehash reports whether encoded was hashed with parameters
+	// that differ from the ones this Hasher currently uses.
+	NeedsRehash(encoded string) bool
+}
+
+// hashers is tried in order by Check/NeedsRehash to find whichever
+// implementation produced a given encoded hash.
+var hashers []Hasher
+
+var defaultHasher Hasher
+
+func init() {
+	argon2idHasher := NewArgon2idHasher(DefaultArgon2idParams())
+	hashers = []Hasher{
+		argon2idHasher,
+		NewScryptHasher(DefaultScryptParams()),
+		NewBcryptHasher(bcrypt.DefaultCost),
+	}
+	defaultHasher = argon2idHasher
+}
+
+// SetDefault configures the Hasher used by Make, and the one
+// NeedsRehash upgrades other algorithms' hashes towards. If h isn't
+// already one of the built-in hashers, it's also added to the dispatch
+// list used by Check so its own hashes keep verifying.
+func SetDefault(h Hasher) {
+	defaultHasher = h
+	for _, existing := range hashers {
+		if existing == h {
+			return
+		}
 	}
-	return string(hash), nil
+	hashers = append([]Hasher{h}, hashers...)
 }
 
-func Check(plainText string, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(plainText))
-	return err == nil
+func Make(password string) (string, error) {
+	return defaultHasher.Hash(password)
+}
+
+func Check(password string, encoded string) bool {
+	for _, h := range hashers {
+		if h.Matches(encoded) {
+			ok, err := h.Verify(password, encoded)
+			return err == nil && ok
+		}
+	}
+	return false
+}
+
+// NeedsRehash reports whether encoded should be re-hashed with Make on
+// the caller's next successful Check: true if it was produced by an
+// algorithm other than the current default, or by the default with
+// parameters that differ from its current ones. Applications typically call
+// this right after a successful Check and, if true, call Make again and
+// store the new hash.
+func NeedsRehash(encoded string) bool {
+	for _, h := range hashers {
+		if h.Matches(encoded) {
+			return h != defaultHasher || h.NeedsRehash(encoded)
+		}
+	}
+	return true
 }
@@ -0,0 +1,93 @@
+package hash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMakeAndCheckRoundTrip(t *testing.T) {
+	encoded, err := Make("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if !Check("correct horse battery staple", encoded) {
+		t.Errorf("Check: expected the original password to verify")
+	}
+	if Check("wrong password", encoded) {
+		t.Errorf("Check: expected a wrong password not to verify")
+	}
+}
+
+func TestCheckDispatchesAcrossAlgorithms(t *testing.T) {
+	bcryptEncoded, err := NewBcryptHasher(bcryptTestCost).Hash("hunter2")
+	if err != nil {
+		t.Fatalf("bcrypt Hash: %v", err)
+	}
+	scryptEncoded, err := NewScryptHasher(ScryptParams{N: 16, R: 8, P: 1, SaltLength: 16, KeyLength: 32}).Hash("hunter2")
+	if err != nil {
+		t.Fatalf("scrypt Hash: %v", err)
+	}
+
+	if !Check("hunter2", bcryptEncoded) {
+		t.Errorf("Check: expected bcrypt hash to verify")
+	}
+	if !Check("hunter2", scryptEncoded) {
+		t.Errorf("Check: expected scrypt hash to verify")
+	}
+}
+
+func TestNeedsRehashUpgradesToDefault(t *testing.T) {
+	bcryptEncoded, err := NewBcryptHasher(bcryptTestCost).Hash("hunter2")
+	if err != nil {
+		t.Fatalf("bcrypt Hash: %v", err)
+	}
+	if !NeedsRehash(bcryptEncoded) {
+		t.Errorf("NeedsRehash: expected a non-default algorithm's hash to need rehashing")
+	}
+
+	encoded, err := Make("hunter2")
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if NeedsRehash(encoded) {
+		t.Errorf("NeedsRehash: expected a freshly made default hash not to need rehashing")
+	}
+}
+
+// bcryptTestCost keeps bcrypt fast enough for the test suite; production
+// code should use bcrypt.DefaultCost or higher.
+const bcryptTestCost = 4
+
+func TestArgon2idHashingTimeScalesWithIterations(t *testing.T) {
+	low := Argon2idParams{Memory: 8 * 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	high := low
+	high.Iterations = 16
+
+	// A single sample of each is noisy under scheduler jitter, so take
+	// the fastest of a few runs for each side - the fastest run is the
+	// one least disturbed by outside noise, and Iterations=16 doing
+	// 16x the work of Iterations=1 should still come out ahead.
+	lowElapsed := fastestHashTime(t, low)
+	highElapsed := fastestHashTime(t, high)
+
+	if highElapsed < lowElapsed {
+		t.Errorf("hashing with Iterations=%d (%v, fastest of several runs) took less time than Iterations=%d (%v)", high.Iterations, highElapsed, low.Iterations, lowElapsed)
+	}
+}
+
+func fastestHashTime(t *testing.T, params Argon2idParams) time.Duration {
+	t.Helper()
+	hasher := &argon2idHasher{params: params}
+	var fastest time.Duration
+	for i := 0; i < 5; i++ {
+		began := time.Now()
+		if _, err := hasher.Hash("timing-benchmark"); err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		elapsed := time.Since(began)
+		if fastest == 0 || elapsed < fastest {
+			fastest = elapsed
+		}
+	}
+	return fastest
+}
@@ -0,0 +1,102 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ScryptParams controls the cost parameters used when hashing with
+// scrypt. See golang.org/x/crypto/scrypt for what each one means.
+type ScryptParams struct {
+	N          int // CPU/memory cost, must be a power of two
+	R          int // block size
+	P          int // parallelization
+	SaltLength int
+	KeyLength  int
+}
+
+// DefaultScryptParams returns N=2^15, r=8, p=1, the parameters
+// recommended by the scrypt paper for interactive logins.
+func DefaultScryptParams() ScryptParams {
+	return ScryptParams{N: 32768, R: 8, P: 1, SaltLength: 16, KeyLength: 32}
+}
+
+type scryptHasher struct {
+	params ScryptParams
+}
+
+// NewScryptHasher builds a Hasher backed by scrypt with the given
+// parameters.
+func NewScryptHasher(params ScryptParams) Hasher {
+	return &scryptHasher{params: params}
+}
+
+func (s *scryptHasher) Hash(password string) (string, error) {
+	salt := make([]byte, s.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := scrypt.Key([]byte(password), salt, s.params.N, s.params.R, s.params.P, s.params.KeyLength)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"$scrypt$n=%d,r=%d,p=%d$%s$%s",
+		s.params.N, s.params.R, s.params.P,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (s *scryptHasher) Verify(password string, encoded string) (bool, error) {
+	params, salt, key, err := decodeScrypt(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, len(key))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
+
+func (s *scryptHasher) Matches(encoded string) bool {
+	return strings.HasPrefix(encoded, "$scrypt$")
+}
+
+func (s *scryptHasher) NeedsRehash(encoded string) bool {
+	params, _, _, err := decodeScrypt(encoded)
+	if err != nil {
+		return true
+	}
+	return params.N != s.params.N || params.R != s.params.R || params.P != s.params.P
+}
+
+func decodeScrypt(encoded string) (ScryptParams, []byte, []byte, error) {
+	// $scrypt$n=32768,r=8,p=1$<salt>$<hash>
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return ScryptParams{}, nil, nil, fmt.Errorf("hash: not a scrypt PHC string")
+	}
+
+	var params ScryptParams
+	if _, err := fmt.Sscanf(parts[2], "n=%d,r=%d,p=%d", &params.N, &params.R, &params.P); err != nil {
+		return ScryptParams{}, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return ScryptParams{}, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return ScryptParams{}, nil, nil, err
+	}
+
+	return params, salt, key, nil
+}
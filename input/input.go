@@ -1,45 +1,292 @@
 package input
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"reflect"
+	"strconv"
 )
 
+// defaultMaxBodyBytes caps how much of a request body Parse reads,
+// guarding against an oversized body exhausting memory.
+const defaultMaxBodyBytes = 10 << 20 // 10 MiB
+
 type InputInterface interface {
 	Get(key string) string
+	GetAll(key string) []string
 	Has(key string) bool
 	All() map[string]string
+	GetJSON(key string, v interface{}) error
+	File(key string) (multipart.File, *multipart.FileHeader, error)
 }
 
+// input stores every value as whatever shape it was received in -
+// []string for a form field (repeated checkbox groups included), or the
+// raw decoded interface{} for a JSON field, which keeps arrays and
+// nested objects intact instead of collapsing them into empty strings.
 type input struct {
-	values map[string]string
+	values map[string]interface{}
+	files  map[string][]*multipart.FileHeader
+}
+
+// Parse reads r's body - form-encoded, multipart/form-data, or JSON -
+// into an InputInterface, capping the body at defaultMaxBodyBytes.
+func Parse(r *http.Request) (*input, error) {
+	return ParseWithLimit(r, defaultMaxBodyBytes)
 }
 
-func Parse(r *http.Request) *input {
+// ParseWithLimit is Parse with an explicit body size limit, in bytes.
+func ParseWithLimit(r *http.Request, maxBodyBytes int64) (*input, error) {
+	if err := capBody(r, maxBodyBytes); err != nil {
+		return nil, err
+	}
+
+	mediaType, err := contentMediaType(r)
+	if err != nil {
+		return nil, err
+	}
 
-	contentType := r.Header.Get("Content-Type")
-	inputs := make(map[string]string)
+	in := &input{
+		values: make(map[string]interface{}),
+		files:  make(map[string][]*multipart.FileHeader),
+	}
 
-	if contentType == "application/json" {
-		json.NewDecoder(r.Body).Decode(&inputs)
-	} else {
-		r.ParseForm()
-		for fieldName := range r.Form {
-			inputs[fieldName] = r.Form.Get(fieldName)
+	switch mediaType {
+	case "application/json":
+		var decoded map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&decoded); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("input: could not decode JSON body: %w", err)
+		}
+		for key, value := range decoded {
+			in.values[key] = value
+		}
+
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(maxBodyBytes); err != nil {
+			return nil, fmt.Errorf("input: could not parse multipart form: %w", err)
+		}
+		for key, values := range r.MultipartForm.Value {
+			in.values[key] = values
+		}
+		for key, files := range r.MultipartForm.File {
+			in.files[key] = files
+		}
+
+	default:
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("input: could not parse form: %w", err)
+		}
+		for key, values := range r.PostForm {
+			in.values[key] = values
 		}
 	}
 
-	return &input{inputs}
+	return in, nil
+}
+
+// capBody reads r.Body into memory up to maxBodyBytes, erroring instead
+// of silently truncating if it's exceeded, then rewinds r.Body so the
+// rest of Parse can read it again (ParseForm/ParseMultipartForm/the JSON
+// decoder all consume it directly).
+func capBody(r *http.Request, maxBodyBytes int64) error {
+	if r.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		return fmt.Errorf("input: could not read request body: %w", err)
+	}
+	if int64(len(body)) > maxBodyBytes {
+		return fmt.Errorf("input: request body exceeds %d byte limit", maxBodyBytes)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
+// contentMediaType returns r's Content-Type with any parameters (e.g.
+// "; charset=utf-8") stripped off, so callers sending
+// "application/json; charset=utf-8" aren't rejected by an exact string
+// match. An empty Content-Type is returned as "", which Parse sends down
+// its form branch; r.ParseForm leaves a body with no Content-Type unparsed.
+func contentMediaType(r *http.Request) (string, error) {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return "", nil
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return "", fmt.Errorf("input: could not parse Content-Type %q: %w", ct, err)
+	}
+	return mediaType, nil
 }
 
 func (i *input) Get(key string) string {
-	return i.values[key]
+	return scalarString(i.values[key])
+}
+
+// GetAll returns every value submitted for key, e.g. for a repeated
+// checkbox group (field=a&field=b) or a JSON array field.
+func (i *input) GetAll(key string) []string {
+	v, ok := i.values[key]
+	if !ok {
+		return nil
+	}
+	switch vv := v.(type) {
+	case []string:
+		return vv
+	case []interface{}:
+		all := make([]string, len(vv))
+		for idx, elem := range vv {
+			all[idx] = scalarString(elem)
+		}
+		return all
+	default:
+		return []string{scalarString(v)}
+	}
 }
 
 func (i *input) Has(key string) bool {
-	return i.values[key] != ""
+	return i.Get(key) != ""
 }
 
 func (i *input) All() map[string]string {
-	return i.values
+	all := make(map[string]string, len(i.values))
+	for key, value := range i.values {
+		all[key] = scalarString(value)
+	}
+	return all
+}
+
+// GetJSON decodes key's value into v. A JSON field's decoded value (a
+// scalar, array, or nested object) round-trips through v as-is. A form
+// field is always stored as []string, though, so binding a single-valued
+// field like "age=42" to a scalar v unwraps the lone element first -
+// otherwise v would see a one-element JSON array and fail to unmarshal
+// into, say, an int - and if v is itself numeric or boolean, the
+// unwrapped form string is decoded as a raw JSON literal ("42", not
+// "\"42\"") rather than quoted text. Both steps apply to JSON fields
+// too: a single-element JSON array binds to a scalar v as its element,
+// and a JSON string bound to a numeric or boolean v is decoded as a
+// raw literal.
+func (i *input) GetJSON(key string, v interface{}) error {
+	raw, ok := i.values[key]
+	if !ok {
+		return fmt.Errorf("input: no value for %q", key)
+	}
+	data, err := jsonBytesFor(raw, v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// jsonBytesFor renders raw as the JSON bytes GetJSON should decode into
+// v, unwrapping a single-element []string/[]interface{} and, for a
+// numeric/bool v, passing a form value's string through as a raw JSON
+// literal instead of a quoted one.
+func jsonBytesFor(raw interface{}, v interface{}) ([]byte, error) {
+	unwrapped := unwrapSingleValue(raw, v)
+	if s, ok := unwrapped.(string); ok && wantsRawJSONLiteral(v) {
+		return []byte(s), nil
+	}
+	return json.Marshal(unwrapped)
+}
+
+// unwrapSingleValue returns raw[0] in place of a single-element
+// []string/[]interface{} when v isn't itself a slice/array (or pointer
+// to one), so a form field's single submitted value can bind to a
+// scalar target instead of always marshaling as a JSON array.
+func unwrapSingleValue(raw interface{}, v interface{}) interface{} {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt != nil && (rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array) {
+		return raw
+	}
+
+	switch vv := raw.(type) {
+	case []string:
+		if len(vv) == 1 {
+			return vv[0]
+		}
+	case []interface{}:
+		if len(vv) == 1 {
+			return vv[0]
+		}
+	}
+	return raw
+}
+
+// wantsRawJSONLiteral reports whether v (a pointer to the GetJSON
+// target) is numeric or boolean, the cases where a form value's string
+// needs to be decoded as a bare JSON literal rather than JSON text.
+func wantsRawJSONLiteral(v interface{}) bool {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == nil {
+		return false
+	}
+	switch rt.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// File opens the first uploaded file for a multipart/form-data field
+// named key. The caller is responsible for closing the returned file.
+func (i *input) File(key string) (multipart.File, *multipart.FileHeader, error) {
+	files, ok := i.files[key]
+	if !ok || len(files) == 0 {
+		return nil, nil, fmt.Errorf("input: no file for %q", key)
+	}
+	fh := files[0]
+	f, err := fh.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, fh, nil
+}
+
+// scalarString renders a stored value as a single string for Get/All,
+// taking the first element of a multi-value field and rendering
+// non-string JSON scalars (numbers, booleans) in their natural form.
+// Nested objects have no single scalar representation and render as "".
+func scalarString(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return vv
+	case []string:
+		if len(vv) == 0 {
+			return ""
+		}
+		return vv[0]
+	case []interface{}:
+		if len(vv) == 0 {
+			return ""
+		}
+		return scalarString(vv[0])
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(vv)
+	case map[string]interface{}:
+		return ""
+	default:
+		return fmt.Sprintf("%v", vv)
+	}
 }
@@ -0,0 +1,168 @@
+package input
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseFormEncoded(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Ada&tag=a&tag=b"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	in, err := Parse(r)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if got := in.Get("name"); got != "Ada" {
+		t.Errorf(`Get("name") = %q, want "Ada"`, got)
+	}
+	if got := in.GetAll("tag"); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf(`GetAll("tag") = %v, want ["a" "b"]`, got)
+	}
+	if !in.Has("name") {
+		t.Errorf(`Has("name") = false, want true`)
+	}
+	if in.Has("missing") {
+		t.Errorf(`Has("missing") = true, want false`)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	body := `{"name":"Ada","age":42,"tags":["a","b"]}`
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	in, err := Parse(r)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if got := in.Get("name"); got != "Ada" {
+		t.Errorf(`Get("name") = %q, want "Ada"`, got)
+	}
+	if got := in.GetAll("tags"); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf(`GetAll("tags") = %v, want ["a" "b"]`, got)
+	}
+
+	var age int
+	if err := in.GetJSON("age", &age); err != nil {
+		t.Fatalf("GetJSON(age): %v", err)
+	}
+	if age != 42 {
+		t.Errorf("age = %d, want 42", age)
+	}
+
+	var tags []string
+	if err := in.GetJSON("tags", &tags); err != nil {
+		t.Fatalf("GetJSON(tags): %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Errorf("tags = %v, want [a b]", tags)
+	}
+}
+
+func TestGetJSONUnwrapsSingleValuedFormField(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("age=42"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	in, err := Parse(r)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var age int
+	if err := in.GetJSON("age", &age); err != nil {
+		t.Fatalf("GetJSON(age): %v", err)
+	}
+	if age != 42 {
+		t.Errorf("age = %d, want 42", age)
+	}
+}
+
+func TestGetJSONKeepsMultiValuedFormFieldAsArray(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("tag=a&tag=b"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	in, err := Parse(r)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var tags []string
+	if err := in.GetJSON("tag", &tags); err != nil {
+		t.Fatalf("GetJSON(tag): %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Errorf("tags = %v, want [a b]", tags)
+	}
+}
+
+func TestParseMultipartFormWithFile(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("name", "Ada"); err != nil {
+		t.Fatalf("WriteField: %v", err)
+	}
+	fw, err := w.CreateFormFile("upload", "hello.txt")
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	if _, err := fw.Write([]byte("hello world")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	in, err := Parse(r)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if got := in.Get("name"); got != "Ada" {
+		t.Errorf(`Get("name") = %q, want "Ada"`, got)
+	}
+
+	f, fh, err := in.File("upload")
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	defer f.Close()
+	if fh.Filename != "hello.txt" {
+		t.Errorf("Filename = %q, want hello.txt", fh.Filename)
+	}
+	contents, err := readAll(f)
+	if err != nil {
+		t.Fatalf("readAll: %v", err)
+	}
+	if contents != "hello world" {
+		t.Errorf("contents = %q, want %q", contents, "hello world")
+	}
+
+	if _, _, err := in.File("missing"); err == nil {
+		t.Errorf("File(missing): expected an error")
+	}
+}
+
+func TestParseWithLimitRejectsOversizedBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Ada&extra=morethanfitsinthelimit"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if _, err := ParseWithLimit(r, 5); err == nil {
+		t.Errorf("ParseWithLimit: expected an error for a body over the limit")
+	}
+}
+
+func readAll(f multipart.File) (string, error) {
+	var buf bytes.Buffer
+	_, err := buf.ReadFrom(f)
+	return buf.String(), err
+}
@@ -0,0 +1,56 @@
+package validator
+
+import "testing"
+
+func TestValidateWithLocaleUsesRegisteredMessage(t *testing.T) {
+	RegisterLocale("es", map[string]string{"required": "El campo %s es obligatorio."})
+
+	_, errs := ValidateWithLocale(map[string]string{}, map[string]string{"name": "required"}, "es")
+	if got, want := errs["name"], "El campo name es obligatorio."; got != want {
+		t.Errorf("errs[name] = %q, want %q", got, want)
+	}
+}
+
+func TestValidateWithLocaleFallsBackToEnglishForUnregisteredLocale(t *testing.T) {
+	_, errs := ValidateWithLocale(map[string]string{}, map[string]string{"name": "required"}, "fr")
+	if got, want := errs["name"], "The name field is required."; got != want {
+		t.Errorf("errs[name] = %q, want %q", got, want)
+	}
+}
+
+func TestValidateWithLocaleFallsBackToEnglishForUnregisteredKey(t *testing.T) {
+	RegisterLocale("de", map[string]string{"email": "Das %s muss eine gültige E-Mail-Adresse sein."})
+
+	_, errs := ValidateWithLocale(map[string]string{}, map[string]string{"name": "required"}, "de")
+	if got, want := errs["name"], "The name field is required."; got != want {
+		t.Errorf("errs[name] = %q, want %q", got, want)
+	}
+}
+
+func TestValidateWithLocaleEmptyLocaleUsesEnglish(t *testing.T) {
+	ok, errs := Validate(map[string]string{}, map[string]string{"name": "required"})
+	if ok {
+		t.Fatalf("expected validation to fail")
+	}
+	if got, want := errs["name"], "The name field is required."; got != want {
+		t.Errorf("errs[name] = %q, want %q", got, want)
+	}
+}
+
+func TestSetTranslatorOverridesDefault(t *testing.T) {
+	original := translator
+	defer func() { translator = original }()
+
+	SetTranslator(stubTranslator{})
+
+	_, errs := ValidateWithLocale(map[string]string{}, map[string]string{"name": "required"}, "any")
+	if got, want := errs["name"], "stub: name"; got != want {
+		t.Errorf("errs[name] = %q, want %q", got, want)
+	}
+}
+
+type stubTranslator struct{}
+
+func (stubTranslator) Translate(locale string, rule string) (string, bool) {
+	return "stub: %s", true
+}
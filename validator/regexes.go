@@ -0,0 +1,29 @@
+package validator
+
+import "regexp"
+
+// Precompiled regexes shared by the validators below. Compiling these
+// once at package init avoids recompiling the same pattern on every
+// Validate call, which matters for rules that run per-field-per-request.
+var (
+	alphaRegex         = regexp.MustCompile("^[a-zA-Z]+$")
+	alphaDashRegex     = regexp.MustCompile("^[a-zA-Z0-9-_]+$")
+	alphaNumericRegex  = regexp.MustCompile("^[a-zA-Z0-9]+$")
+	digitsRegex        = regexp.MustCompile("^[0-9]+$")
+	emailRegex         = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	uuidRegex          = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	uuid3Regex         = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-3[0-9a-fA-F]{3}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	uuid4Regex         = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+	uuid5Regex         = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-5[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+	isbn10Regex        = regexp.MustCompile(`^(?:[0-9]{9}X|[0-9]{10})$`)
+	isbn13Regex        = regexp.MustCompile(`^(?:97[89])[0-9]{10}$`)
+	ssnRegex           = regexp.MustCompile(`^\d{3}-\d{2}-\d{4}$`)
+	latitudeRegex      = regexp.MustCompile(`^[-+]?([1-8]?\d(\.\d+)?|90(\.0+)?)$`)
+	longitudeRegex     = regexp.MustCompile(`^[-+]?(180(\.0+)?|((1[0-7]\d)|([1-9]?\d))(\.\d+)?)$`)
+	datauriRegex       = regexp.MustCompile(`^data:[a-zA-Z]+/[a-zA-Z0-9.+-]+;base64,[a-zA-Z0-9+/]+={0,2}$`)
+	asciiRegex         = regexp.MustCompile("^[\x00-\x7F]*$")
+	printAsciiRegex    = regexp.MustCompile("^[\x20-\x7E]*$")
+	base64Regex        = regexp.MustCompile(`^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=|[A-Za-z0-9+/]{4})$`)
+	macRegex           = regexp.MustCompile(`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`)
+	hexColorRegex      = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
@@ -0,0 +1,252 @@
+package validator
+
+import "testing"
+
+func runValidatorCases(t *testing.T, fn Validator, cases []struct {
+	value string
+	want  bool
+}) {
+	t.Helper()
+	for _, tt := range cases {
+		got := fn("field", tt.value, nil, nil)
+		if got != tt.want {
+			t.Errorf("value %q: got %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidateUuid(t *testing.T) {
+	runValidatorCases(t, validateUuid, []struct {
+		value string
+		want  bool
+	}{
+		{"123e4567-e89b-12d3-a456-426614174000", true},
+		{"not-a-uuid", false},
+		{"", false},
+	})
+}
+
+func TestValidateUuid3(t *testing.T) {
+	runValidatorCases(t, validateUuid3, []struct {
+		value string
+		want  bool
+	}{
+		{"a3bb189e-8bf9-3888-9912-ace4e6543002", true},
+		{"a3bb189e-8bf9-4888-9912-ace4e6543002", false},
+	})
+}
+
+func TestValidateUuid4(t *testing.T) {
+	runValidatorCases(t, validateUuid4, []struct {
+		value string
+		want  bool
+	}{
+		{"e5f6a7b8-c9d0-4e1f-a2b3-c4d5e6f7a8b9", true},
+		{"e5f6a7b8-c9d0-3e1f-a2b3-c4d5e6f7a8b9", false},
+	})
+}
+
+func TestValidateUuid5(t *testing.T) {
+	runValidatorCases(t, validateUuid5, []struct {
+		value string
+		want  bool
+	}{
+		{"886313e1-3b8a-5372-9b90-0c9aee199e5d", true},
+		{"886313e1-3b8a-4372-9b90-0c9aee199e5d", false},
+	})
+}
+
+func TestValidateIsbn10(t *testing.T) {
+	runValidatorCases(t, validateIsbn10, []struct {
+		value string
+		want  bool
+	}{
+		{"0306406152", true},
+		{"0306406153", false},
+		{"abcdefghij", false},
+	})
+}
+
+func TestValidateIsbn13(t *testing.T) {
+	runValidatorCases(t, validateIsbn13, []struct {
+		value string
+		want  bool
+	}{
+		{"9780306406157", true},
+		{"9780306406158", false},
+	})
+}
+
+func TestValidateIsbn(t *testing.T) {
+	runValidatorCases(t, validateIsbn, []struct {
+		value string
+		want  bool
+	}{
+		{"0306406152", true},
+		{"9780306406157", true},
+		{"not-an-isbn", false},
+	})
+}
+
+func TestValidateSsn(t *testing.T) {
+	runValidatorCases(t, validateSsn, []struct {
+		value string
+		want  bool
+	}{
+		{"123-45-6789", true},
+		{"123456789", false},
+	})
+}
+
+func TestValidateLatitude(t *testing.T) {
+	runValidatorCases(t, validateLatitude, []struct {
+		value string
+		want  bool
+	}{
+		{"45.5231", true},
+		{"-90", true},
+		{"90.1", false},
+	})
+}
+
+func TestValidateLongitude(t *testing.T) {
+	runValidatorCases(t, validateLongitude, []struct {
+		value string
+		want  bool
+	}{
+		{"-122.6765", true},
+		{"180", true},
+		{"180.1", false},
+	})
+}
+
+func TestValidateDataUri(t *testing.T) {
+	runValidatorCases(t, validateDataUri, []struct {
+		value string
+		want  bool
+	}{
+		{"data:text/plain;base64,SGVsbG8=", true},
+		{"not-a-data-uri", false},
+	})
+}
+
+func TestValidateAscii(t *testing.T) {
+	runValidatorCases(t, validateAscii, []struct {
+		value string
+		want  bool
+	}{
+		{"hello world", true},
+		{"héllo", false},
+	})
+}
+
+func TestValidatePrintAscii(t *testing.T) {
+	runValidatorCases(t, validatePrintAscii, []struct {
+		value string
+		want  bool
+	}{
+		{"hello world", true},
+		{"hello\tworld", false},
+	})
+}
+
+func TestValidateMultibyte(t *testing.T) {
+	runValidatorCases(t, validateMultibyte, []struct {
+		value string
+		want  bool
+	}{
+		{"héllo", true},
+		{"hello", false},
+	})
+}
+
+func TestValidateBase64(t *testing.T) {
+	runValidatorCases(t, validateBase64, []struct {
+		value string
+		want  bool
+	}{
+		{"SGVsbG8gd29ybGQ=", true},
+		{"not base64!!", false},
+		{"", false},
+	})
+}
+
+func TestValidateCreditCard(t *testing.T) {
+	runValidatorCases(t, validateCreditCard, []struct {
+		value string
+		want  bool
+	}{
+		{"4111111111111111", true},
+		{"4111111111111112", false},
+	})
+}
+
+func TestValidateJson(t *testing.T) {
+	runValidatorCases(t, validateJson, []struct {
+		value string
+		want  bool
+	}{
+		{`{"a":1}`, true},
+		{`[1,2,3]`, true},
+		{`not json`, false},
+	})
+}
+
+func TestValidateMac(t *testing.T) {
+	runValidatorCases(t, validateMac, []struct {
+		value string
+		want  bool
+	}{
+		{"01:23:45:67:89:ab", true},
+		{"01-23-45-67-89-ab", false},
+	})
+}
+
+func TestValidateCidr(t *testing.T) {
+	runValidatorCases(t, validateCidr, []struct {
+		value string
+		want  bool
+	}{
+		{"192.168.1.0/24", true},
+		{"192.168.1.0", false},
+	})
+}
+
+func TestValidateHexColor(t *testing.T) {
+	runValidatorCases(t, validateHexColor, []struct {
+		value string
+		want  bool
+	}{
+		{"#fff", true},
+		{"#ffffff", true},
+		{"#gggggg", false},
+	})
+}
+
+func TestValidateContains(t *testing.T) {
+	got := validateContains("field", "hello world", nil, []string{"world"})
+	if !got {
+		t.Errorf("expected contains to match")
+	}
+	if validateContains("field", "hello world", nil, []string{"bye"}) {
+		t.Errorf("expected contains not to match")
+	}
+}
+
+func TestValidateStartsWith(t *testing.T) {
+	if !validateStartsWith("field", "hello world", nil, []string{"hello"}) {
+		t.Errorf("expected starts_with to match")
+	}
+	if validateStartsWith("field", "hello world", nil, []string{"world"}) {
+		t.Errorf("expected starts_with not to match")
+	}
+}
+
+func TestValidateEndsWith(t *testing.T) {
+	if !validateEndsWith("field", "hello world", nil, []string{"world"}) {
+		t.Errorf("expected ends_with to match")
+	}
+	if validateEndsWith("field", "hello world", nil, []string{"hello"}) {
+		t.Errorf("expected ends_with not to match")
+	}
+}
@@ -0,0 +1,47 @@
+package validator
+
+import "testing"
+
+// TestValidatePresenceTruthTable exercises the present/empty/required
+// truth table documented on Validate.
+func TestValidatePresenceTruthTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs map[string]string
+		rules  string
+		want   bool
+	}{
+		{"absent + required -> required error", map[string]string{}, "required", false},
+		{"absent + not required -> skipped", map[string]string{}, "email", true},
+		{"absent + not required + always (and no sometimes) -> rules still run", map[string]string{}, "always|email", false},
+		{"present empty + required -> fails required", map[string]string{"field": ""}, "required", false},
+		{"present empty + not required -> skipped", map[string]string{"field": ""}, "email", true},
+		{"present empty + not required + always -> rules run", map[string]string{"field": ""}, "always|email", false},
+		{"present empty + nullable + always -> skip wins", map[string]string{"field": ""}, "always|nullable|email", true},
+		{"present non-empty -> rules run", map[string]string{"field": "not-an-email"}, "email", false},
+		{"present valid -> passes", map[string]string{"field": "a@b.com"}, "email", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := Validate(tt.inputs, map[string]string{"field": tt.rules})
+			if ok != tt.want {
+				t.Fatalf("Validate() ok = %v, errs = %v, want %v", ok, errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSometimesSkipsWhenAbsent(t *testing.T) {
+	ok, errs := Validate(map[string]string{}, map[string]string{"field": "sometimes|email"})
+	if !ok {
+		t.Fatalf("expected \"sometimes\" to skip an absent field, got errs = %v", errs)
+	}
+}
+
+func TestValidateSometimesStillRunsWhenPresent(t *testing.T) {
+	ok, errs := Validate(map[string]string{"field": "not-an-email"}, map[string]string{"field": "sometimes|email"})
+	if ok {
+		t.Fatalf("expected \"sometimes\" to still validate a present value, errs = %v", errs)
+	}
+}
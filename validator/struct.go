@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValidateStruct walks v (a struct, or a pointer to one) recursively —
+// including nested structs, pointers, and slice/map elements — and
+// validates each leaf field against the rules in its `validate` struct
+// tag. Field paths use dotted notation for nested structs (address.zip)
+// and bracketed indices for slice/map elements (items[2].sku), and that
+// same path is what a "same:" rule must reference to compare against
+// another field.
+//
+// Unlike Validate, which keeps only one message per field (that of the
+// last failing rule), ValidateStruct collects every failing rule's message.
+func ValidateStruct(v interface{}) (bool, map[string][]string) {
+	inputs := make(map[string]string)
+	rules := make(map[string]string)
+	leaves := make(map[string]reflect.Value)
+	collectFields(reflect.ValueOf(v), "", inputs, rules, leaves)
+
+	errMessages := make(map[string][]string)
+	for fieldName, fieldRulesRaw := range rules {
+		fieldValue, fieldExists := inputs[fieldName]
+		fieldRules := strings.Split(fieldRulesRaw, "|")
+		fieldIsRequired := stringInSlice("required", fieldRules)
+		alwaysValidate := stringInSlice("always", fieldRules)
+		isNullable := stringInSlice("nullable", fieldRules)
+		isSometimes := stringInSlice("sometimes", fieldRules)
+
+		// "sometimes" takes priority over "required" when a field is
+		// absent: a nil pointer leaf means the caller didn't supply
+		// that optional field at all, so "sometimes|required" skips it
+		// entirely rather than reporting it missing.
+		if isSometimes && !fieldExists {
+			continue
+		}
+		if fieldIsRequired && !fieldExists {
+			errMessages[fieldName] = append(errMessages[fieldName], buildErrorMessage(fieldName, "required", []string{}, ""))
+			continue
+		}
+		if !fieldIsRequired && fieldValue == "" && (isNullable || !alwaysValidate) {
+			continue
+		}
+		for _, ruleWithParams := range fieldRules {
+			rule, params := splitRuleParams(ruleWithParams)
+
+			if handled, ok := evaluateNumericRule(leaves[fieldName], rule, params); handled {
+				if !ok {
+					errMessages[fieldName] = append(errMessages[fieldName], buildErrorMessage(fieldName, rule, params, ""))
+				}
+				continue
+			}
+
+			if vFn, vExists := validators[rule]; vExists {
+				if !vFn(fieldName, fieldValue, inputs, params) {
+					errMessages[fieldName] = append(errMessages[fieldName], buildErrorMessage(fieldName, rule, params, ""))
+				}
+			}
+		}
+	}
+	return len(errMessages) == 0, errMessages
+}
+
+// evaluateNumericRule compares min_value/max_value/value/value_between
+// directly against rv's native int/uint/float representation instead of
+// going through the string-based validators, which round-trip through
+// float64 and lose precision for integers beyond 2^53 (e.g. an int64
+// field just below a large min_value would otherwise wrongly compare
+// equal). handled is false for any other rule or a non-numeric rv, in
+// which case the caller should fall back to the string-based validator.
+func evaluateNumericRule(rv reflect.Value, rule string, params []string) (handled bool, ok bool) {
+	switch rule {
+	case "min_value", "max_value", "value", "value_between":
+	default:
+		return false, false
+	}
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false, false
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true, evaluateIntRule(rv.Int(), rule, params)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true, evaluateUintRule(rv.Uint(), rule, params)
+	case reflect.Float32, reflect.Float64:
+		return true, evaluateFloatRule(rv.Float(), rule, params)
+	default:
+		return false, false
+	}
+}
+
+func evaluateIntRule(v int64, rule string, params []string) bool {
+	switch rule {
+	case "min_value":
+		p, err := parseIntParam(params, 0)
+		return err == nil && v >= p
+	case "max_value":
+		p, err := parseIntParam(params, 0)
+		return err == nil && v <= p
+	case "value":
+		p, err := parseIntParam(params, 0)
+		return err == nil && v == p
+	case "value_between":
+		p1, err1 := parseIntParam(params, 0)
+		p2, err2 := parseIntParam(params, 1)
+		return err1 == nil && err2 == nil && v >= p1 && v <= p2
+	}
+	return false
+}
+
+func evaluateUintRule(v uint64, rule string, params []string) bool {
+	switch rule {
+	case "min_value":
+		p, err := parseUintParam(params, 0)
+		return err == nil && v >= p
+	case "max_value":
+		p, err := parseUintParam(params, 0)
+		return err == nil && v <= p
+	case "value":
+		p, err := parseUintParam(params, 0)
+		return err == nil && v == p
+	case "value_between":
+		p1, err1 := parseUintParam(params, 0)
+		p2, err2 := parseUintParam(params, 1)
+		return err1 == nil && err2 == nil && v >= p1 && v <= p2
+	}
+	return false
+}
+
+func evaluateFloatRule(v float64, rule string, params []string) bool {
+	switch rule {
+	case "min_value":
+		p, err := parseFloatParam(params, 0)
+		return err == nil && v >= p
+	case "max_value":
+		p, err := parseFloatParam(params, 0)
+		return err == nil && v <= p
+	case "value":
+		p, err := parseFloatParam(params, 0)
+		return err == nil && v == p
+	case "value_between":
+		p1, err1 := parseFloatParam(params, 0)
+		p2, err2 := parseFloatParam(params, 1)
+		return err1 == nil && err2 == nil && v >= p1 && v <= p2
+	}
+	return false
+}
+
+func parseIntParam(params []string, i int) (int64, error) {
+	if i >= len(params) {
+		return 0, fmt.Errorf("validator: missing parameter %d", i)
+	}
+	return strconv.ParseInt(params[i], 10, 64)
+}
+
+func parseUintParam(params []string, i int) (uint64, error) {
+	if i >= len(params) {
+		return 0, fmt.Errorf("validator: missing parameter %d", i)
+	}
+	return strconv.ParseUint(params[i], 10, 64)
+}
+
+func parseFloatParam(params []string, i int) (float64, error) {
+	if i >= len(params) {
+		return 0, fmt.Errorf("validator: missing parameter %d", i)
+	}
+	return strconv.ParseFloat(params[i], 64)
+}
+
+// collectFields walks rv recursively, populating inputs with a string
+// representation of every leaf value (keyed by its dotted/bracketed
+// path), rules with the `validate` tag of every leaf that has one, and
+// leaves with the leaf's own reflect.Value so numeric rules can compare
+// against it directly instead of through inputs' stringified copy.
+func collectFields(rv reflect.Value, prefix string, inputs map[string]string, rules map[string]string, leaves map[string]reflect.Value) {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		rt := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			field := rt.Field(i)
+			if field.PkgPath != "" {
+				// unexported field
+				continue
+			}
+			name := field.Tag.Get("json")
+			if idx := strings.Index(name, ","); idx >= 0 {
+				name = name[:idx]
+			}
+			if name == "" || name == "-" {
+				name = field.Name
+			}
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+
+			fieldValue := rv.Field(i)
+			if validate := field.Tag.Get("validate"); validate != "" {
+				rules[path] = validate
+				// A nil pointer leaf has no value to validate, so it's
+				// left out of inputs/leaves entirely rather than stored
+				// as "" - the same "absent" state a plain Validate call
+				// sees for a key missing from its inputs map. Without
+				// this, "sometimes"/"required" could never tell a nil
+				// *T apart from a supplied-but-empty one.
+				if !isNilPointer(fieldValue) {
+					inputs[path] = fieldToString(fieldValue)
+					leaves[path] = fieldValue
+				}
+			}
+			collectFields(fieldValue, path, inputs, rules, leaves)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collectFields(rv.Index(i), fmt.Sprintf("%s[%d]", prefix, i), inputs, rules, leaves)
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			collectFields(rv.MapIndex(key), fmt.Sprintf("%s[%v]", prefix, key.Interface()), inputs, rules, leaves)
+		}
+	default:
+		if prefix != "" {
+			inputs[prefix] = fieldToString(rv)
+		}
+	}
+}
+
+// isNilPointer reports whether rv is a nil pointer.
+func isNilPointer(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// fieldToString renders a reflected leaf value the same way it would
+// appear as a form or JSON string input, so the existing string-based
+// validators can run against it unchanged.
+func fieldToString(rv reflect.Value) string {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return ""
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	default:
+		return fmt.Sprintf("%v", rv.Interface())
+	}
+}
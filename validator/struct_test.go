@@ -0,0 +1,73 @@
+package validator
+
+import "testing"
+
+func TestValidateStructInt64PrecisionNotLostThroughString(t *testing.T) {
+	type payload struct {
+		ID int64 `json:"id" validate:"min_value:9007199254740993"`
+	}
+
+	// ID is one less than the minimum: a float64 round-trip loses the
+	// precision to tell these two int64 values apart, and would
+	// wrongly report this as valid.
+	ok, errs := ValidateStruct(payload{ID: 9007199254740992})
+	if ok {
+		t.Fatalf("expected validation to fail, got ok = true, errs = %v", errs)
+	}
+
+	ok, errs = ValidateStruct(payload{ID: 9007199254740993})
+	if !ok {
+		t.Fatalf("expected validation to pass, got errs = %v", errs)
+	}
+}
+
+func TestValidateStructNilPointerIsTreatedAsAbsent(t *testing.T) {
+	type payload struct {
+		Name *string `json:"name" validate:"sometimes|required"`
+	}
+
+	ok, errs := ValidateStruct(payload{Name: nil})
+	if !ok {
+		t.Fatalf("expected a nil pointer field with \"sometimes\" to be skipped, got errs = %v", errs)
+	}
+
+	name := "ada"
+	ok, errs = ValidateStruct(payload{Name: &name})
+	if !ok {
+		t.Fatalf("expected a supplied pointer field to pass, got errs = %v", errs)
+	}
+}
+
+func TestValidateStructRequiredNilPointerStillFails(t *testing.T) {
+	type payload struct {
+		Name *string `json:"name" validate:"required"`
+	}
+
+	ok, errs := ValidateStruct(payload{Name: nil})
+	if ok {
+		t.Fatalf("expected a nil pointer field with plain \"required\" to fail")
+	}
+	if len(errs["name"]) == 0 {
+		t.Fatalf("expected a required error for name, got %v", errs)
+	}
+}
+
+func TestValidateStructNumericRules(t *testing.T) {
+	type payload struct {
+		Age   int     `json:"age" validate:"value_between:18,65"`
+		Score float64 `json:"score" validate:"max_value:99.5"`
+	}
+
+	ok, errs := ValidateStruct(payload{Age: 30, Score: 99.5})
+	if !ok {
+		t.Fatalf("expected validation to pass, got errs = %v", errs)
+	}
+
+	ok, errs = ValidateStruct(payload{Age: 90, Score: 100})
+	if ok {
+		t.Fatalf("expected validation to fail")
+	}
+	if len(errs["age"]) == 0 || len(errs["score"]) == 0 {
+		t.Fatalf("expected errors for both age and score, got %v", errs)
+	}
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"reflect"
@@ -8,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Validator func(name string, value string, inputs map[string]string, params []string) bool
@@ -18,30 +20,53 @@ var validators = map[string]Validator{
 	"alpha":          validateAlpha,
 	"alpha_dash":     validateAlphaDash,
 	"alpha_num":      validateAlphaNumeric,
+	"ascii":          validateAscii,
+	"base64":         validateBase64,
 	"boolean":        validateBoolean,
 	"chars":          validateChars,
 	"chars_between":  validateCharsBetween,
+	"cidr":           validateCidr,
 	"confirmed":      validateConfirmed,
+	"contains":       validateContains,
+	"credit_card":    validateCreditCard,
 	"date":           validateDate,
+	"datauri":        validateDataUri,
 	"different":      validateDifferent,
 	"digits":         validateDigits,
 	"digits_between": validateDigitsBetween,
 	"email":          validateEmail,
+	"ends_with":      validateEndsWith,
+	"hex_color":      validateHexColor,
 	"in":             validateIn,
 	"integer":        validateInteger,
 	"ip":             validateIp,
+	"isbn":           validateIsbn,
+	"isbn10":         validateIsbn10,
+	"isbn13":         validateIsbn13,
+	"json":           validateJson,
+	"latitude":       validateLatitude,
+	"longitude":      validateLongitude,
+	"mac":            validateMac,
 	"max_chars":      validateMaxChars,
 	"max_digits":     validateMaxDigits,
 	"max_value":      validateMaxValue,
 	"min_chars":      validateMinChars,
 	"min_digits":     validateMinDigits,
 	"min_value":      validateMinValue,
+	"multibyte":      validateMultibyte,
 	"not_in":         validateNotIn,
 	"numeric":        validateNumeric,
+	"printascii":     validatePrintAscii,
 	"regex":          validateRegex,
 	"required":       validateRequired,
 	"same":           validateSame,
+	"ssn":            validateSsn,
+	"starts_with":    validateStartsWith,
 	"url":            validateUrl,
+	"uuid":           validateUuid,
+	"uuid3":          validateUuid3,
+	"uuid4":          validateUuid4,
+	"uuid5":          validateUuid5,
 	"value":          validateValue,
 	"value_between":  validateValueBetween,
 }
@@ -52,16 +77,31 @@ var messages = map[string]string{
 	"alpha":          "The %s may only contain letters.",
 	"alpha_dash":     "The %s may only contain letters, numbers, and dashes.",
 	"alpha_num":      "The %s may only contain letters and numbers.",
+	"ascii":          "The %s must only contain ASCII characters.",
+	"base64":         "The %s must be valid base64 data.",
 	"boolean":        "The %s field must be true or false.",
 	"chars":          "The %s field must have %s characters.",
 	"chars_between":  "The %s field must have between %s characters.",
+	"cidr":           "The %s must be a valid CIDR notation.",
 	"confirmed":      "The %s confirmation does not match.",
+	"contains":       "The %s must contain %s.",
+	"credit_card":    "The %s must be a valid credit card number.",
 	"date":           "The %s is not a valid date.",
+	"datauri":        "The %s must be a valid data URI.",
 	"different":      "The %s and %s must be different.",
 	"digits":         "The %s must have %s digits.",
 	"digits_between": "The %s must have between %s and %s digits.",
 	"email":          "The %s must be a valid email address.",
+	"ends_with":      "The %s must end with %s.",
+	"hex_color":      "The %s must be a valid hex color.",
 	"in":             "The selected %s is invalid.",
+	"isbn":           "The %s must be a valid ISBN.",
+	"isbn10":         "The %s must be a valid ISBN-10.",
+	"isbn13":         "The %s must be a valid ISBN-13.",
+	"json":           "The %s must be valid JSON.",
+	"latitude":       "The %s must be a valid latitude.",
+	"longitude":      "The %s must be a valid longitude.",
+	"mac":            "The %s must be a valid MAC address.",
 	"max_chars":      "The %s must have fewer than %s characters.",
 	"max_digits":     "The %s must have fewer than %s digits.",
 	"max_value":      "The %s must be less than %s.",
@@ -70,12 +110,20 @@ var messages = map[string]string{
 	"min_value":      "The %s must be greater than %s.",
 	"integer":        "The %s must be an integer.",
 	"ip":             "The %s must be a valid IP address.",
+	"multibyte":      "The %s must contain multibyte characters.",
 	"not_in":         "The selected %s is invalid.",
 	"numeric":        "The %s must be a number.",
+	"printascii":     "The %s must only contain printable ASCII characters.",
 	"regex":          "The %s format is invalid.",
 	"required":       "The %s field is required.",
 	"same":           "The %s and %s must match.",
+	"ssn":            "The %s must be a valid SSN.",
+	"starts_with":    "The %s must start with %s.",
 	"url":            "The %s format is invalid.",
+	"uuid":           "The %s must be a valid UUID.",
+	"uuid3":          "The %s must be a valid version 3 UUID.",
+	"uuid4":          "The %s must be a valid version 4 UUID.",
+	"uuid5":          "The %s must be a valid version 5 UUID.",
 	"value":          "The %s must %s.",
 	"value_between":  "The %s must be between %s and %s.",
 }
@@ -85,6 +133,47 @@ func AddValidator(name string, fn Validator, message string) {
 	messages[name] = message
 }
 
+// Translator resolves a message template for a rule in a given locale.
+// The default translator is a simple map registry populated via
+// RegisterLocale; callers needing something more dynamic (gettext,
+// a translation service, etc.) can provide their own implementation
+// via SetTranslator.
+type Translator interface {
+	Translate(locale string, rule string) (string, bool)
+}
+
+type mapTranslator struct {
+	locales map[string]map[string]string
+}
+
+func (t *mapTranslator) Translate(locale string, rule string) (string, bool) {
+	ruleMessages, localeExists := t.locales[locale]
+	if !localeExists {
+		return "", false
+	}
+	message, ruleExists := ruleMessages[rule]
+	return message, ruleExists
+}
+
+var translator Translator = &mapTranslator{locales: make(map[string]map[string]string)}
+
+// SetTranslator overrides the default map-based translator.
+func SetTranslator(t Translator) {
+	translator = t
+}
+
+// RegisterLocale registers a set of rule -> message templates for a
+// locale, e.g. RegisterLocale("es", map[string]string{"required": "El
+// campo %s es obligatorio."}). Messages missing from a locale fall back
+// to the default English messages map.
+func RegisterLocale(locale string, localeMessages map[string]string) {
+	mt, ok := translator.(*mapTranslator)
+	if !ok {
+		return
+	}
+	mt.locales[locale] = localeMessages
+}
+
 func validateAccepted(name string, value string, inputs map[string]string, params []string) bool {
 	valid := []string{"1", "true", "yes", "on"}
 	return stringInSlice(value, valid)
@@ -103,15 +192,23 @@ func validateActiveUrl(name string, value string, inputs map[string]string, para
 }
 
 func validateAlpha(name string, value string, inputs map[string]string, params []string) bool {
-	return regexp.MustCompile("^[a-zA-Z]+$").MatchString(value)
+	return alphaRegex.MatchString(value)
 }
 
 func validateAlphaDash(name string, value string, inputs map[string]string, params []string) bool {
-	return regexp.MustCompile("^[a-zA-Z0-9-_]+$").MatchString(value)
+	return alphaDashRegex.MatchString(value)
 }
 
 func validateAlphaNumeric(name string, value string, inputs map[string]string, params []string) bool {
-	return regexp.MustCompile("^[a-zA-Z0-9]+$").MatchString(value)
+	return alphaNumericRegex.MatchString(value)
+}
+
+func validateAscii(name string, value string, inputs map[string]string, params []string) bool {
+	return asciiRegex.MatchString(value)
+}
+
+func validateBase64(name string, value string, inputs map[string]string, params []string) bool {
+	return value != "" && base64Regex.MatchString(value)
 }
 
 func validateBoolean(name string, value string, inputs map[string]string, params []string) bool {
@@ -136,11 +233,31 @@ func validateCharsBetween(name string, value string, inputs map[string]string, p
 	return false
 }
 
+func validateCidr(name string, value string, inputs map[string]string, params []string) bool {
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
 func validateConfirmed(name string, value string, inputs map[string]string, params []string) bool {
 	fieldValue, fieldExists := inputs[name+"_confirmation"]
 	return fieldExists && fieldValue == value
 }
 
+func validateContains(name string, value string, inputs map[string]string, params []string) bool {
+	if len(params) == 1 {
+		return strings.Contains(value, params[0])
+	}
+	return false
+}
+
+func validateCreditCard(name string, value string, inputs map[string]string, params []string) bool {
+	return luhnValid(value)
+}
+
+func validateDataUri(name string, value string, inputs map[string]string, params []string) bool {
+	return datauriRegex.MatchString(value)
+}
+
 func validateDate(name string, value string, inputs map[string]string, params []string) bool {
 	if len(params) == 1 {
 		_, err := time.Parse(params[0], value)
@@ -154,7 +271,7 @@ func validateDifferent(name string, value string, inputs map[string]string, para
 }
 
 func validateDigits(name string, value string, inputs map[string]string, params []string) bool {
-	if len(params) == 1 && regexp.MustCompile("^[0-9]+$").MatchString(value) {
+	if len(params) == 1 && digitsRegex.MatchString(value) {
 		digitCount, err := strconv.ParseInt(params[0], 10, 16)
 		return err == nil && int64(len(value)) == digitCount
 	}
@@ -171,7 +288,18 @@ func validateDigitsBetween(name string, value string, inputs map[string]string,
 }
 
 func validateEmail(name string, value string, inputs map[string]string, params []string) bool {
-	return regexp.MustCompile("^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$").MatchString(value)
+	return emailRegex.MatchString(value)
+}
+
+func validateEndsWith(name string, value string, inputs map[string]string, params []string) bool {
+	if len(params) == 1 {
+		return strings.HasSuffix(value, params[0])
+	}
+	return false
+}
+
+func validateHexColor(name string, value string, inputs map[string]string, params []string) bool {
+	return hexColorRegex.MatchString(value)
 }
 
 func validateIn(name string, value string, inputs map[string]string, params []string) bool {
@@ -187,16 +315,76 @@ func validateIp(name string, value string, inputs map[string]string, params []st
 	return net.ParseIP(value) != nil
 }
 
+func validateIsbn(name string, value string, inputs map[string]string, params []string) bool {
+	return validateIsbn10(name, value, inputs, params) || validateIsbn13(name, value, inputs, params)
+}
+
+func validateIsbn10(name string, value string, inputs map[string]string, params []string) bool {
+	if !isbn10Regex.MatchString(value) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(value[i]-'0') * (10 - i)
+	}
+	if value[9] == 'X' {
+		sum += 10
+	} else {
+		sum += int(value[9] - '0')
+	}
+	return sum%11 == 0
+}
+
+func validateIsbn13(name string, value string, inputs map[string]string, params []string) bool {
+	if !isbn13Regex.MatchString(value) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 13; i++ {
+		digit := int(value[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+func validateJson(name string, value string, inputs map[string]string, params []string) bool {
+	return json.Valid([]byte(value))
+}
+
+func validateLatitude(name string, value string, inputs map[string]string, params []string) bool {
+	return latitudeRegex.MatchString(value)
+}
+
+func validateLongitude(name string, value string, inputs map[string]string, params []string) bool {
+	return longitudeRegex.MatchString(value)
+}
+
+func validateMac(name string, value string, inputs map[string]string, params []string) bool {
+	return macRegex.MatchString(value)
+}
+
+func validateMultibyte(name string, value string, inputs map[string]string, params []string) bool {
+	for _, r := range value {
+		if r >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
 func validateMinChars(name string, value string, inputs map[string]string, params []string) bool {
 	if len(params) == 1 {
-		minChars, err := strconv.ParseInt(params[1], 10, 16)
+		minChars, err := strconv.ParseInt(params[0], 10, 16)
 		return err == nil && int64(len(value)) >= minChars
 	}
 	return false
 }
 
 func validateMinDigits(name string, value string, inputs map[string]string, params []string) bool {
-	if len(params) == 1 && regexp.MustCompile("^[0-9]+$").MatchString(value) {
+	if len(params) == 1 && digitsRegex.MatchString(value) {
 		minDigits, err := strconv.ParseInt(params[0], 10, 16)
 		return err == nil && int64(len(value)) >= minDigits
 	}
@@ -214,14 +402,14 @@ func validateMinValue(name string, value string, inputs map[string]string, param
 
 func validateMaxChars(name string, value string, inputs map[string]string, params []string) bool {
 	if len(params) == 1 {
-		maxChars, err := strconv.ParseInt(params[1], 10, 16)
+		maxChars, err := strconv.ParseInt(params[0], 10, 16)
 		return err == nil && int64(len(value)) <= maxChars
 	}
 	return false
 }
 
 func validateMaxDigits(name string, value string, inputs map[string]string, params []string) bool {
-	if len(params) == 1 && regexp.MustCompile("^[0-9]+$").MatchString(value) {
+	if len(params) == 1 && digitsRegex.MatchString(value) {
 		maxDigits, err := strconv.ParseInt(params[0], 10, 16)
 		return err == nil && int64(len(value)) <= maxDigits
 	}
@@ -246,6 +434,10 @@ func validateNumeric(name string, value string, inputs map[string]string, params
 	return err == nil
 }
 
+func validatePrintAscii(name string, value string, inputs map[string]string, params []string) bool {
+	return printAsciiRegex.MatchString(value)
+}
+
 func validateRegex(name string, value string, inputs map[string]string, params []string) bool {
 	if len(params) == 1 {
 		rx, err := regexp.Compile(params[0])
@@ -266,6 +458,17 @@ func validateSame(name string, value string, inputs map[string]string, params []
 	return false
 }
 
+func validateSsn(name string, value string, inputs map[string]string, params []string) bool {
+	return ssnRegex.MatchString(value)
+}
+
+func validateStartsWith(name string, value string, inputs map[string]string, params []string) bool {
+	if len(params) == 1 {
+		return strings.HasPrefix(value, params[0])
+	}
+	return false
+}
+
 func validateUrl(name string, value string, inputs map[string]string, params []string) bool {
 	lc := strings.ToLower(value)
 	if validScheme := strings.HasPrefix(lc, "http://") || strings.HasPrefix(lc, "https://"); validScheme {
@@ -274,6 +477,22 @@ func validateUrl(name string, value string, inputs map[string]string, params []s
 	return false
 }
 
+func validateUuid(name string, value string, inputs map[string]string, params []string) bool {
+	return uuidRegex.MatchString(value)
+}
+
+func validateUuid3(name string, value string, inputs map[string]string, params []string) bool {
+	return uuid3Regex.MatchString(value)
+}
+
+func validateUuid4(name string, value string, inputs map[string]string, params []string) bool {
+	return uuid4Regex.MatchString(value)
+}
+
+func validateUuid5(name string, value string, inputs map[string]string, params []string) bool {
+	return uuid5Regex.MatchString(value)
+}
+
 func validateValue(name string, value string, inputs map[string]string, params []string) bool {
 	if len(params) == 1 {
 		expectedValue, evErr := strconv.ParseFloat(params[0], 16)
@@ -292,9 +511,33 @@ func validateValueBetween(name string, value string, inputs map[string]string, p
 	return false
 }
 
+// Validate processes rules against inputs and reports whether each field
+// is present, empty, and required decides whether its rules run at all:
+//
+//	present  empty  required  rules run?
+//	no       -      yes       no  (a "required" error is reported instead)
+//	no       -      no        no, unless "sometimes" is absent and "always" is given
+//	yes      yes    yes       yes (the value will fail validateRequired)
+//	yes      yes    no        no, unless "always" is given ("nullable" forces the skip back on)
+//	yes      no     yes/no    yes
+//
+// "nullable" and "sometimes" let callers opt into this skip-when-empty
+// behaviour explicitly instead of relying on the "always" rule, which
+// forces rules to run even against an empty value (e.g. an optional
+// "email" field would fail an email rule for value ""). "sometimes"
+// additionally skips the field whenever it wasn't supplied at all,
+// overriding "always" for that case too.
 func Validate(inputs map[string]string, rules map[string]string) (bool, map[string]string) {
+	return ValidateWithLocale(inputs, rules, "")
+}
+
+// ValidateWithLocale behaves like Validate but builds error messages from
+// the locale registered via RegisterLocale, falling back to the default
+// English messages map when the locale or a specific rule key hasn't
+// been registered.
+func ValidateWithLocale(inputs map[string]string, rules map[string]string, locale string) (bool, map[string]string) {
 	// initialize an error messages map
-	messages := make(map[string]string)
+	errMessages := make(map[string]string)
 	for fieldName, fieldRulesRaw := range rules {
 		// process each rule
 		// start by extracting relevant field info
@@ -302,11 +545,22 @@ func Validate(inputs map[string]string, rules map[string]string) (bool, map[stri
 		fieldRules := strings.Split(fieldRulesRaw, "|")
 		fieldIsRequired := stringInSlice("required", fieldRules)
 		alwaysValidate := stringInSlice("always", fieldRules)
+		isNullable := stringInSlice("nullable", fieldRules)
+		isSometimes := stringInSlice("sometimes", fieldRules)
+		if isSometimes && !fieldExists {
+			// "sometimes": nothing to validate if the field wasn't supplied,
+			// even when it's also marked "required"
+			continue
+		}
 		if fieldIsRequired && !fieldExists {
 			// add message saying field is required
 			// don't worry about the value, that will be handled below
-			messages[fieldName] = buildErrorMessage(fieldName, "required", []string{})
-		} else if fieldIsRequired || alwaysValidate || (!fieldIsRequired && fieldValue != "") {
+			errMessages[fieldName] = buildErrorMessage(fieldName, "required", []string{}, locale)
+		} else if !fieldIsRequired && fieldValue == "" && (isNullable || !alwaysValidate) {
+			// field is present-or-not but empty, and not required: skip
+			// the remaining rules unless "always" forces them to run
+			continue
+		} else {
 			// process the rules
 			for i := 0; i < len(fieldRules); i++ {
 				rule, params := splitRuleParams(fieldRules[i])
@@ -314,14 +568,14 @@ func Validate(inputs map[string]string, rules map[string]string) (bool, map[stri
 					// specified validator exists, call it
 					if !vFn(fieldName, fieldValue, inputs, params) {
 						// validation failed, add a message
-						messages[fieldName] = buildErrorMessage(fieldName, rule, params)
+						errMessages[fieldName] = buildErrorMessage(fieldName, rule, params, locale)
 					}
 				}
 			}
 		}
 	}
 	// validation succeeded if there are no error messages
-	return len(messages) == 0, messages
+	return len(errMessages) == 0, errMessages
 }
 
 func RulesFromStruct(s interface{}) map[string]string {
@@ -338,8 +592,16 @@ func RulesFromStruct(s interface{}) map[string]string {
 	return rules
 }
 
-func buildErrorMessage(fieldName string, rule string, params []string) string {
-	message, exists := messages[rule]
+func buildErrorMessage(fieldName string, rule string, params []string, locale string) string {
+	message, exists := "", false
+	if locale != "" {
+		message, exists = translator.Translate(locale, rule)
+	}
+	if !exists {
+		// no locale given, or the locale/rule wasn't registered: fall
+		// back to the default English messages
+		message, exists = messages[rule]
+	}
 	if !exists || strings.Count(message, "%s") != 1+len(params) {
 		return fmt.Sprintf("The %s is invalid.", fieldName)
 	}
@@ -370,3 +632,25 @@ func stringInSlice(needle string, haystack []string) bool {
 	}
 	return false
 }
+
+// luhnValid reports whether value is a numeric string that passes the
+// Luhn checksum, as used by credit card numbers.
+func luhnValid(value string) bool {
+	if value == "" || !digitsRegex.MatchString(value) {
+		return false
+	}
+	sum := 0
+	alternate := false
+	for i := len(value) - 1; i >= 0; i-- {
+		digit := int(value[i] - '0')
+		if alternate {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		alternate = !alternate
+	}
+	return sum%10 == 0
+}
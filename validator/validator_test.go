@@ -0,0 +1,28 @@
+package validator
+
+import "testing"
+
+func TestCharsRulesDoNotPanicOnSingleParam(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		value string
+		want  bool
+	}{
+		{"min_chars pass", "min_chars:2", "ab", true},
+		{"min_chars fail", "min_chars:5", "ab", false},
+		{"max_chars pass", "max_chars:5", "ab", true},
+		{"max_chars fail", "max_chars:1", "ab", false},
+		{"chars_between pass", "chars_between:1,5", "ab", true},
+		{"chars_between fail", "chars_between:3,5", "ab", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := Validate(map[string]string{"name": tt.value}, map[string]string{"name": tt.rules})
+			if ok != tt.want {
+				t.Fatalf("Validate() ok = %v, errs = %v, want %v", ok, errs, tt.want)
+			}
+		})
+	}
+}